@@ -0,0 +1,35 @@
+package helix
+
+import "context"
+
+// requestOptions holds the per-call settings that RequestOption can
+// override. It's unexported; callers only ever see RequestOption values.
+type requestOptions struct {
+	ctx context.Context
+}
+
+// RequestOption customizes a single API call without changing the Client's
+// default Options, e.g. to attach a per-call deadline, cancellation, or
+// trace span.
+type RequestOption func(*requestOptions)
+
+// WithContext attaches ctx to a single API call, taking precedence over the
+// context.Context the Client was constructed with (see
+// NewClientWithContext). This is the standard way to give a call its own
+// deadline or cancellation instead of sharing the Client's.
+func WithContext(ctx context.Context) RequestOption {
+	return func(o *requestOptions) {
+		o.ctx = ctx
+	}
+}
+
+// resolveRequestOptions builds the requestOptions for a call, falling back to the
+// Client's own context when the caller didn't supply WithContext.
+func (c *Client) resolveRequestOptions(opts []RequestOption) *requestOptions {
+	ro := &requestOptions{ctx: c.ctx}
+	for _, opt := range opts {
+		opt(ro)
+	}
+
+	return ro
+}
@@ -0,0 +1,31 @@
+package helix
+
+import (
+	"context"
+	"testing"
+)
+
+func TestResolveRequestOptionsDefaultsToClientContext(t *testing.T) {
+	clientCtx := context.WithValue(context.Background(), ctxKey("k"), "client")
+	c := &Client{ctx: clientCtx}
+
+	ro := c.resolveRequestOptions(nil)
+
+	if ro.ctx != clientCtx {
+		t.Errorf("resolveRequestOptions(nil).ctx = %v, want the Client's own context", ro.ctx)
+	}
+}
+
+func TestResolveRequestOptionsWithContextOverridesClientContext(t *testing.T) {
+	clientCtx := context.WithValue(context.Background(), ctxKey("k"), "client")
+	callCtx := context.WithValue(context.Background(), ctxKey("k"), "call")
+	c := &Client{ctx: clientCtx}
+
+	ro := c.resolveRequestOptions([]RequestOption{WithContext(callCtx)})
+
+	if ro.ctx != callCtx {
+		t.Errorf("resolveRequestOptions(WithContext(callCtx)).ctx = %v, want callCtx", ro.ctx)
+	}
+}
+
+type ctxKey string
@@ -0,0 +1,73 @@
+package helix
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors returned (wrapped) by doRequest so callers can use
+// errors.Is instead of comparing Response.StatusCode by hand.
+var (
+	ErrUnauthorized = errors.New("helix: unauthorized")
+	ErrForbidden    = errors.New("helix: forbidden")
+	ErrNotFound     = errors.New("helix: not found")
+	ErrRateLimited  = errors.New("helix: rate limited")
+	ErrServerError  = errors.New("helix: server error")
+)
+
+// APIError is returned by doRequest whenever Twitch responds with a status
+// of 400 or above. It wraps one of the sentinel errors above (when the status code
+// maps to one) so both errors.Is(err, helix.ErrNotFound) and
+// errors.As(err, &apiErr) work against the same error value.
+type APIError struct {
+	StatusCode int
+	Status     string
+	Message    string
+	Endpoint   string
+	Body       []byte
+
+	sentinel error
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("helix: %s %s: %d %s", e.Endpoint, e.Status, e.StatusCode, e.Message)
+}
+
+func (e *APIError) Unwrap() error {
+	return e.sentinel
+}
+
+// errForStatusCode maps an HTTP status code to the sentinel error it should
+// be wrapped around, or nil if the status code isn't one doRequest treats as
+// a typed failure.
+func errForStatusCode(statusCode int) error {
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		if statusCode >= http.StatusInternalServerError {
+			return ErrServerError
+		}
+		return nil
+	}
+}
+
+// newAPIError builds the *APIError for resp, wrapping it around the
+// sentinel error matching resp.StatusCode, if any.
+func newAPIError(endpoint string, resp *Response, body []byte) *APIError {
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     http.StatusText(resp.StatusCode),
+		Message:    resp.ErrorMessage,
+		Endpoint:   endpoint,
+		Body:       body,
+		sentinel:   errForStatusCode(resp.StatusCode),
+	}
+}
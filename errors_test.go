@@ -0,0 +1,78 @@
+package helix
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestErrForStatusCode(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		want       error
+	}{
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusInternalServerError, ErrServerError},
+		{http.StatusBadGateway, ErrServerError},
+		{http.StatusBadRequest, nil},
+		{http.StatusOK, nil},
+	}
+
+	for _, tt := range tests {
+		got := errForStatusCode(tt.statusCode)
+		if got != tt.want {
+			t.Errorf("errForStatusCode(%d) = %v, want %v", tt.statusCode, got, tt.want)
+		}
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	resp := &Response{
+		ResponseCommon: ResponseCommon{
+			StatusCode:   http.StatusNotFound,
+			ErrorMessage: "user not found",
+		},
+	}
+
+	body := []byte(`{"error":"Not Found","status":404,"message":"user not found"}`)
+
+	err := newAPIError("/users", resp, body)
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected errors.Is(err, ErrNotFound) to be true, got false for %v", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected errors.As(err, &apiErr) to succeed")
+	}
+
+	if apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, http.StatusNotFound)
+	}
+	if apiErr.Endpoint != "/users" {
+		t.Errorf("Endpoint = %q, want %q", apiErr.Endpoint, "/users")
+	}
+	if string(apiErr.Body) != string(body) {
+		t.Errorf("Body = %q, want %q", apiErr.Body, body)
+	}
+}
+
+func TestNewAPIErrorNoSentinel(t *testing.T) {
+	resp := &Response{
+		ResponseCommon: ResponseCommon{
+			StatusCode: http.StatusBadRequest,
+		},
+	}
+
+	err := newAPIError("/clips", resp, nil)
+
+	for _, sentinel := range []error{ErrUnauthorized, ErrForbidden, ErrNotFound, ErrRateLimited, ErrServerError} {
+		if errors.Is(err, sentinel) {
+			t.Errorf("errors.Is(err, %v) = true, want false for a 400 with no sentinel mapping", sentinel)
+		}
+	}
+}
@@ -15,6 +15,9 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -34,6 +37,8 @@ type Client struct {
 	ctx          context.Context
 	opts         *Options
 	lastResponse *Response
+	refreshGroup singleflight.Group
+	userAgent    UserAgent
 	callbacks    struct {
 		onUserAccessTokenRefreshed func(newAccessToken, newRefreshToken string)
 	}
@@ -45,12 +50,20 @@ type Options struct {
 	AppAccessToken  string
 	UserAccessToken string
 	RefreshToken    string
-	UserAgent       string
-	RedirectURI     string
-	HTTPClient      HTTPClient
-	RateLimitFunc   RateLimitFunc
-	APIBaseURL      string
-	ExtensionOpts   ExtensionOptions
+	// UserAgent sets the first (outermost) entry of the Client's
+	// composable User-Agent header. It stays a plain string for backward
+	// compatibility with existing helix.Options{UserAgent: "..."}
+	// literals; use Client.SetUserAgent to add further entries after
+	// construction.
+	UserAgent     string
+	RedirectURI   string
+	HTTPClient    HTTPClient
+	RateLimitFunc RateLimitFunc
+	RateLimiter   *rate.Limiter
+	RetryPolicy   *RetryPolicy
+	Middlewares   []Middleware
+	APIBaseURL    string
+	ExtensionOpts ExtensionOptions
 }
 
 type ExtensionOptions struct {
@@ -114,17 +127,26 @@ type Pagination struct {
 	Cursor string `json:"cursor"`
 }
 
+// ClientOption configures Options at construction time, for settings that
+// don't fit naturally as a plain struct field (e.g. a *rate.Limiter shared
+// across multiple Clients). See WithRateLimiter.
+type ClientOption func(*Options)
+
 // NewClient returns a new Twitch Helix API client. It returns an
 // if clientID is an empty string. It is concurrency safe.
-func NewClient(options *Options) (*Client, error) {
-	return NewClientWithContext(context.Background(), options)
+func NewClient(options *Options, opts ...ClientOption) (*Client, error) {
+	return NewClientWithContext(context.Background(), options, opts...)
 }
 
-func NewClientWithContext(ctx context.Context, options *Options) (*Client, error) {
+func NewClientWithContext(ctx context.Context, options *Options, opts ...ClientOption) (*Client, error) {
 	if options.ClientID == "" {
 		return nil, errors.New("A client ID was not provided but is required")
 	}
 
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	if options.HTTPClient == nil {
 		options.HTTPClient = http.DefaultClient
 	}
@@ -133,60 +155,93 @@ func NewClientWithContext(ctx context.Context, options *Options) (*Client, error
 		options.APIBaseURL = DefaultAPIBaseURL
 	}
 
+	if options.RetryPolicy == nil {
+		options.RetryPolicy = DefaultRetryPolicy()
+	}
+
+	if options.RateLimiter == nil {
+		options.RateLimiter = defaultRateLimiter()
+	}
+
 	client := &Client{
 		ctx:  ctx,
 		opts: options,
 	}
 
+	if options.UserAgent != "" {
+		client.userAgent.Prepend(options.UserAgent)
+	}
+
+	// RetryMiddleware and RateLimitMiddleware are the default
+	// implementation of RetryPolicy/RateLimiter; anything registered via
+	// Use ends up wrapped inside them, closer to the transport.
+	//
+	// RetryMiddleware is skipped when the caller still configures the
+	// legacy Options.RateLimitFunc: doRequest's backward-compatible retry
+	// loop around RateLimitFunc already decides when to retry a 429, and
+	// layering RetryMiddleware's own MaxRetries budget underneath it would
+	// silently multiply the number of HTTP attempts per call.
+	defaultMiddlewares := []Middleware{RateLimitMiddleware(client)}
+	if options.RateLimitFunc == nil {
+		defaultMiddlewares = append([]Middleware{RetryMiddleware(options.RetryPolicy)}, defaultMiddlewares...)
+	}
+	options.Middlewares = append(defaultMiddlewares, options.Middlewares...)
+
 	return client, nil
 }
 
-func (c *Client) get(path string, respData, reqData interface{}) (*Response, error) {
-	return c.sendRequest(http.MethodGet, path, respData, reqData, "query")
+func (c *Client) get(path string, respData, reqData interface{}, opts ...RequestOption) (*Response, error) {
+	return c.sendRequest(http.MethodGet, path, respData, reqData, "query", opts)
 }
 
-func (c *Client) post(path string, respData, reqData interface{}) (*Response, error) {
-	return c.sendRequest(http.MethodPost, path, respData, reqData, "query")
+func (c *Client) post(path string, respData, reqData interface{}, opts ...RequestOption) (*Response, error) {
+	return c.sendRequest(http.MethodPost, path, respData, reqData, "query", opts)
 }
 
-func (c *Client) put(path string, respData, reqData interface{}) (*Response, error) {
-	return c.sendRequest(http.MethodPut, path, respData, reqData, "query")
+func (c *Client) put(path string, respData, reqData interface{}, opts ...RequestOption) (*Response, error) {
+	return c.sendRequest(http.MethodPut, path, respData, reqData, "query", opts)
 }
 
-func (c *Client) delete(path string, respData, reqData interface{}) (*Response, error) {
-	return c.sendRequest(http.MethodDelete, path, respData, reqData, "query")
+func (c *Client) delete(path string, respData, reqData interface{}, opts ...RequestOption) (*Response, error) {
+	return c.sendRequest(http.MethodDelete, path, respData, reqData, "query", opts)
 }
 
-func (c *Client) patchAsJSON(path string, respData, reqData interface{}) (*Response, error) {
-	return c.sendRequest(http.MethodPatch, path, respData, reqData, "json")
+func (c *Client) patchAsJSON(path string, respData, reqData interface{}, opts ...RequestOption) (*Response, error) {
+	return c.sendRequest(http.MethodPatch, path, respData, reqData, "json", opts)
 }
 
-func (c *Client) postAsJSON(path string, respData, reqData interface{}) (*Response, error) {
-	return c.sendRequest(http.MethodPost, path, respData, reqData, "json")
+func (c *Client) postAsJSON(path string, respData, reqData interface{}, opts ...RequestOption) (*Response, error) {
+	return c.sendRequest(http.MethodPost, path, respData, reqData, "json", opts)
 }
 
-func (c *Client) putAsJSON(path string, respData, reqData interface{}) (*Response, error) {
-	return c.sendRequest(http.MethodPut, path, respData, reqData, "json")
+func (c *Client) putAsJSON(path string, respData, reqData interface{}, opts ...RequestOption) (*Response, error) {
+	return c.sendRequest(http.MethodPut, path, respData, reqData, "json", opts)
 }
 
-func (c *Client) postAsForm(path string, respData, reqData interface{}) (*Response, error) {
-	return c.sendRequest(http.MethodPost, path, respData, reqData, "form")
+func (c *Client) postAsForm(path string, respData, reqData interface{}, opts ...RequestOption) (*Response, error) {
+	return c.sendRequest(http.MethodPost, path, respData, reqData, "form", opts)
 }
 
-func (c *Client) sendRequest(method, path string, respData, reqData interface{}, bodyType string) (*Response, error) {
+func (c *Client) sendRequest(method, path string, respData, reqData interface{}, bodyType string, opts []RequestOption) (*Response, error) {
 	resp := &Response{}
 	if respData != nil {
 		resp.Data = respData
 	}
 
-	req, err := c.newRequest(method, path, reqData, bodyType)
+	ro := c.resolveRequestOptions(opts)
+
+	req, err := c.newRequest(ro.ctx, method, path, reqData, bodyType)
 	if err != nil {
 		return nil, err
 	}
 
 	err = c.doRequest(req, resp)
 	if err != nil {
-		return nil, err
+		// resp is still returned (and populated) alongside the error for
+		// backward compatibility with callers that inspect
+		// Response.StatusCode/ErrorMessage directly instead of using
+		// errors.Is/errors.As against err.
+		return resp, err
 	}
 
 	return resp, nil
@@ -313,28 +368,28 @@ func isZero(v interface{}) (bool, error) {
 	return v == reflect.Zero(t).Interface(), nil
 }
 
-func (c *Client) newRequest(method, path string, data interface{}, bodyType string) (*http.Request, error) {
+func (c *Client) newRequest(ctx context.Context, method, path string, data interface{}, bodyType string) (*http.Request, error) {
 	url := c.getBaseURL(path) + path
 
 	switch bodyType {
 	case "json":
-		return c.newJSONRequest(method, url, data)
+		return c.newJSONRequest(ctx, method, url, data)
 	case "form":
-		return c.newFormRequest(method, url, data)
+		return c.newFormRequest(ctx, method, url, data)
 	case "query":
 		fallthrough
 	default:
-		return c.newStandardRequest(method, url, data)
+		return c.newStandardRequest(ctx, method, url, data)
 	}
 }
 
-func (c *Client) newFormRequest(method, url string, data interface{}) (*http.Request, error) {
+func (c *Client) newFormRequest(ctx context.Context, method, url string, data interface{}) (*http.Request, error) {
 
 	formData, err := generateFormData(data)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequestWithContext(c.ctx, method, url, strings.NewReader(formData.Encode()))
+	req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(formData.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -354,8 +409,8 @@ func (c *Client) newFormRequest(method, url string, data interface{}) (*http.Req
 	return req, nil
 }
 
-func (c *Client) newStandardRequest(method, url string, data interface{}) (*http.Request, error) {
-	req, err := http.NewRequestWithContext(c.ctx, method, url, nil)
+func (c *Client) newStandardRequest(ctx context.Context, method, url string, data interface{}) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -374,15 +429,13 @@ func (c *Client) newStandardRequest(method, url string, data interface{}) (*http
 	return req, nil
 }
 
-func (c *Client) newJSONRequest(method, url string, data interface{}) (*http.Request, error) {
+func (c *Client) newJSONRequest(ctx context.Context, method, url string, data interface{}) (*http.Request, error) {
 	b, err := json.Marshal(data)
 	if err != nil {
 		return nil, err
 	}
 
-	buf := bytes.NewBuffer(b)
-
-	req, err := http.NewRequestWithContext(c.ctx, method, url, buf)
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(b))
 	if err != nil {
 		return nil, err
 	}
@@ -414,6 +467,9 @@ func (c *Client) doRequest(req *http.Request, resp *Response) error {
 
 	rateLimitFunc := c.opts.RateLimitFunc
 
+	var lastBody []byte
+	first := true
+
 	for {
 		if c.lastResponse != nil && rateLimitFunc != nil {
 			err := rateLimitFunc(c.lastResponse)
@@ -422,7 +478,21 @@ func (c *Client) doRequest(req *http.Request, resp *Response) error {
 			}
 		}
 
-		response, err := c.opts.HTTPClient.Do(req)
+		if !first && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return err
+			}
+			req.Body = body
+		}
+		first = false
+
+		// Retrying on transient failures/429/5xx and pacing against the
+		// configured rate limiter both happen inside this chain (see
+		// RetryMiddleware/RateLimitMiddleware). This loop only repeats a
+		// request after a 401 token refresh, or after a 429 when the legacy
+		// RateLimitFunc is set.
+		response, err := c.roundTripper()(req)
 		if err != nil {
 			return fmt.Errorf("Failed to execute API request: %s", err.Error())
 		}
@@ -436,6 +506,7 @@ func (c *Client) doRequest(req *http.Request, resp *Response) error {
 		if err != nil {
 			return err
 		}
+		lastBody = bodyBytes
 
 		// Only attempt to decode the response if we have a response we can handle
 		if len(bodyBytes) > 0 && resp.StatusCode < http.StatusInternalServerError {
@@ -448,9 +519,17 @@ func (c *Client) doRequest(req *http.Request, resp *Response) error {
 				if resp.StatusCode == http.StatusUnauthorized && c.canRefreshToken() {
 					if refreshErr := c.refreshToken(); refreshErr != nil {
 						log.Printf("Failed to refresh helix auth token: %v", refreshErr)
-						return err
+						// The refresh failed, so surface the original 401
+						// to the caller instead of a nil error.
+						if unmarshalErr := json.Unmarshal(bodyBytes, &resp); unmarshalErr != nil {
+							return fmt.Errorf("Failed to decode API response: %s", unmarshalErr.Error())
+						}
+						return newAPIError(req.URL.Path, resp, bodyBytes)
 					}
-					// Try again now that we have a new token
+					// Try again now that we have a new token. setRequestHeaders
+					// takes the lock itself for the piece that needs it, so
+					// another goroutine's refresh (via singleflight) racing
+					// ahead of us is picked up without double-locking.
 					c.setRequestHeaders(req)
 					continue
 				}
@@ -482,6 +561,13 @@ func (c *Client) doRequest(req *http.Request, resp *Response) error {
 		}
 	}
 
+	// Populate Response for backward compatibility and also surface a typed
+	// error so callers can use errors.Is/errors.As instead of inspecting
+	// StatusCode by hand.
+	if resp.StatusCode >= http.StatusBadRequest {
+		return newAPIError(req.URL.Path, resp, lastBody)
+	}
+
 	return nil
 }
 
@@ -492,28 +578,44 @@ func (c *Client) canRefreshToken() bool {
 		c.opts.RefreshToken != ""
 }
 
+// refreshToken refreshes the current user access token. Concurrent callers
+// that observe the same refresh token are collapsed into a single refresh
+// via singleflight, so N goroutines seeing a 401 at once don't each burn the
+// refresh token and race each other; the losers block and pick up whatever
+// token the winner obtained.
 func (c *Client) refreshToken() error {
-	resp, err := c.RefreshUserAccessToken(c.opts.RefreshToken)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		statusCode := -1
-		var errorMessage string
-		if resp != nil {
-			statusCode = resp.StatusCode
-			errorMessage = resp.ErrorMessage
+	c.mu.RLock()
+	key := c.opts.RefreshToken
+	c.mu.RUnlock()
+
+	_, err, _ := c.refreshGroup.Do(key, func() (interface{}, error) {
+		resp, err := c.RefreshUserAccessToken(key)
+		if err != nil || resp.StatusCode != http.StatusOK {
+			statusCode := -1
+			var errorMessage string
+			if resp != nil {
+				statusCode = resp.StatusCode
+				errorMessage = resp.ErrorMessage
+			}
+			return nil, fmt.Errorf("failed to refresh token: (%d: %s) %v", statusCode, errorMessage, err)
 		}
-		return fmt.Errorf("failed to refresh token: (%d: %s) %v", statusCode, errorMessage, err)
-	}
 
-	c.mu.Lock()
-	c.opts.UserAccessToken = resp.Data.AccessToken
-	c.opts.RefreshToken = resp.Data.RefreshToken
-	c.mu.Unlock()
+		c.mu.Lock()
+		c.opts.UserAccessToken = resp.Data.AccessToken
+		c.opts.RefreshToken = resp.Data.RefreshToken
+		c.mu.Unlock()
 
-	if cb := c.callbacks.onUserAccessTokenRefreshed; cb != nil {
-		go cb(resp.Data.AccessToken, resp.Data.RefreshToken)
-	}
+		// Fire the callback from inside the singleflight closure so it runs
+		// exactly once per real refresh, not once per goroutine that asked
+		// for one.
+		if cb := c.callbacks.onUserAccessTokenRefreshed; cb != nil {
+			go cb(resp.Data.AccessToken, resp.Data.RefreshToken)
+		}
 
-	return nil
+		return resp, nil
+	})
+
+	return err
 }
 
 func (c *Client) setRequestHeaders(req *http.Request) {
@@ -521,9 +623,13 @@ func (c *Client) setRequestHeaders(req *http.Request) {
 
 	req.Header.Set("Client-ID", opts.ClientID)
 
-	if opts.UserAgent != "" {
-		req.Header.Set("User-Agent", opts.UserAgent)
-	}
+	c.mu.RLock()
+	req.Header.Set("User-Agent", c.userAgent.Join())
+	// Read the tokens below from a copy taken under the lock, so a token
+	// written by a concurrent refreshToken is seen without a data race.
+	snapshot := *c.opts
+	opts = &snapshot
+	c.mu.RUnlock()
 
 	var bearerToken string
 	if opts.AppAccessToken != "" {
@@ -597,10 +703,14 @@ func (c *Client) SetExtensionSignedJWTToken(jwt string) {
 	c.opts.ExtensionOpts.SignedJWTToken = jwt
 }
 
+// SetUserAgent adds another identifier to the Client's composable
+// User-Agent. Call it more than once to stack identifiers (e.g. app +
+// sub-library); every call keeps the existing ones and the default
+// helix/X.Y.Z suffix.
 func (c *Client) SetUserAgent(userAgent string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.opts.UserAgent = userAgent
+	c.userAgent.Prepend(userAgent)
 }
 
 func (c *Client) SetRedirectURI(uri string) {
@@ -0,0 +1,118 @@
+package helix
+
+import "context"
+
+// PageFunc fetches a single page for an Iter. after is the cursor from the
+// previous page's Pagination ("" for the first page). Implementations
+// typically call a Client's get/post helpers, which already go through
+// doRequest and so pick up whatever RetryPolicy/RateLimiter/RateLimitFunc
+// the Client is configured with.
+type PageFunc[T any] func(ctx context.Context, after string) (items []T, cursor string, page *ResponseCommon, err error)
+
+// Iter is a generic cursor-based pagination iterator for Helix endpoints
+// that return a Pagination{Cursor}, e.g. followers, subscriptions, videos,
+// and clips. Construct one with NewIter and drive it with Next/Value:
+//
+//	it := helix.NewIter(func(ctx context.Context, after string) ([]Item, string, *helix.ResponseCommon, error) {
+//		// Request one page, sending after as the "after" query parameter,
+//		// and return its items, its Pagination.Cursor and its ResponseCommon.
+//	})
+//	for it.Next(ctx) {
+//		use(it.Value())
+//	}
+//	if it.Err() != nil { ... }
+type Iter[T any] struct {
+	fetch PageFunc[T]
+
+	items []T
+	index int
+
+	after   string
+	started bool
+	done    bool
+
+	page *ResponseCommon
+	err  error
+}
+
+// NewIter constructs an Iter driven by fetch.
+func NewIter[T any](fetch PageFunc[T]) *Iter[T] {
+	return &Iter[T]{fetch: fetch, index: -1}
+}
+
+// Next advances the iterator, fetching the next page when the current one
+// is exhausted. It returns false once there are no more items or an error
+// occurred; check Err() to distinguish the two.
+func (it *Iter[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.index++
+	if it.index < len(it.items) {
+		return true
+	}
+
+	if it.started && it.after == "" {
+		it.done = true
+	}
+
+	if it.done {
+		return false
+	}
+
+	items, cursor, page, err := it.fetch(ctx, it.after)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.started = true
+	it.items = items
+	it.index = 0
+	it.after = cursor
+	it.page = page
+
+	if len(items) == 0 {
+		it.done = true
+		return false
+	}
+
+	return true
+}
+
+// Value returns the current item. It's only valid after a call to Next that
+// returned true.
+func (it *Iter[T]) Value() T {
+	return it.items[it.index]
+}
+
+// Err returns the error, if any, that stopped the iterator.
+func (it *Iter[T]) Err() error {
+	return it.err
+}
+
+// Page returns the ResponseCommon for the page the current item came from,
+// e.g. to inspect rate limit headers.
+func (it *Iter[T]) Page() *ResponseCommon {
+	return it.page
+}
+
+// CollectAll drains the iterator into a slice, stopping after max items (or
+// when the iterator is exhausted if max <= 0).
+func (it *Iter[T]) CollectAll(ctx context.Context, max int) ([]T, error) {
+	var all []T
+
+	for it.Next(ctx) {
+		all = append(all, it.Value())
+		if max > 0 && len(all) >= max {
+			break
+		}
+	}
+
+	if it.Err() != nil {
+		return all, it.Err()
+	}
+
+	return all, nil
+}
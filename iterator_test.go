@@ -0,0 +1,108 @@
+package helix
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func newPagedFetch(pages [][]int) PageFunc[int] {
+	i := 0
+	return func(ctx context.Context, after string) ([]int, string, *ResponseCommon, error) {
+		if i >= len(pages) {
+			return nil, "", nil, nil
+		}
+		items := pages[i]
+		i++
+		cursor := ""
+		if i < len(pages) {
+			cursor = "cursor"
+		}
+		return items, cursor, &ResponseCommon{StatusCode: 200}, nil
+	}
+}
+
+func TestIterStopsWhenCursorIsEmpty(t *testing.T) {
+	it := NewIter(newPagedFetch([][]int{{1, 2}, {3}}))
+
+	var got []int
+	for it.Next(context.Background()) {
+		got = append(got, it.Value())
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatalf("Err() = %v, want nil", err)
+	}
+
+	want := []int{1, 2, 3}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+
+	if it.Next(context.Background()) {
+		t.Fatal("Next() = true after iterator exhausted, want false")
+	}
+}
+
+func TestIterStopsOnEmptyPage(t *testing.T) {
+	fetch := func(ctx context.Context, after string) ([]int, string, *ResponseCommon, error) {
+		return nil, "should-not-be-used", &ResponseCommon{StatusCode: 200}, nil
+	}
+
+	it := NewIter(fetch)
+
+	if it.Next(context.Background()) {
+		t.Fatal("Next() = true on a page with zero items, want false")
+	}
+	if it.Err() != nil {
+		t.Fatalf("Err() = %v, want nil", it.Err())
+	}
+}
+
+func TestIterStopsOnError(t *testing.T) {
+	wantErr := errors.New("boom")
+	fetch := func(ctx context.Context, after string) ([]int, string, *ResponseCommon, error) {
+		return nil, "", nil, wantErr
+	}
+
+	it := NewIter(fetch)
+
+	if it.Next(context.Background()) {
+		t.Fatal("Next() = true after fetch error, want false")
+	}
+	if !errors.Is(it.Err(), wantErr) {
+		t.Fatalf("Err() = %v, want %v", it.Err(), wantErr)
+	}
+	if it.Next(context.Background()) {
+		t.Fatal("Next() = true after error was already surfaced, want false")
+	}
+}
+
+func TestIterCollectAllRespectsMax(t *testing.T) {
+	it := NewIter(newPagedFetch([][]int{{1, 2}, {3, 4}, {5}}))
+
+	got, err := it.CollectAll(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("CollectAll() error = %v, want nil", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("CollectAll() = %v, want 3 items", got)
+	}
+}
+
+func TestIterCollectAllDrainsWhenMaxIsZero(t *testing.T) {
+	it := NewIter(newPagedFetch([][]int{{1, 2}, {3}}))
+
+	got, err := it.CollectAll(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("CollectAll() error = %v, want nil", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("CollectAll() = %v, want 3 items", got)
+	}
+}
@@ -0,0 +1,154 @@
+package helix
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httputil"
+)
+
+// RoundTripFunc performs a single HTTP round trip. It has the same shape as
+// http.Client.Do, which lets a chain of Middleware wrap the final call to
+// Options.HTTPClient the same way http.RoundTripper composes transports.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps a RoundTripFunc to observe or modify a request/response
+// pair — logging, tracing, metrics, request-id propagation, caching, or
+// recording/replaying fixtures in tests.
+type Middleware func(RoundTripFunc) RoundTripFunc
+
+// Use registers a Middleware. Middlewares run in the order they were added,
+// with the first one registered being the outermost wrapper around the
+// final call to Options.HTTPClient.
+func (c *Client) Use(m Middleware) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.opts.Middlewares = append(c.opts.Middlewares, m)
+}
+
+// roundTripper composes the registered middlewares around the Client's
+// HTTPClient into a single RoundTripFunc for doRequest to call.
+func (c *Client) roundTripper() RoundTripFunc {
+	c.mu.RLock()
+	httpClient := c.opts.HTTPClient
+	middlewares := append([]Middleware(nil), c.opts.Middlewares...)
+	c.mu.RUnlock()
+
+	next := RoundTripFunc(httpClient.Do)
+
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		next = middlewares[i](next)
+	}
+
+	return next
+}
+
+// RetryMiddleware builds a Middleware that retries requests according to
+// policy: attempts that policy's CheckRetry accepts (by default network
+// errors and 429/500/502/503/504 responses) are retried with backoff,
+// honoring Ratelimit-Reset for 429s (see RetryPolicy). It rewinds the
+// request body between attempts via req.GetBody, same as doRequest did
+// before this moved out of the hardcoded loop. This is installed by default
+// in NewClientWithContext using Options.RetryPolicy.
+func RetryMiddleware(policy *RetryPolicy) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			var lastResponse *http.Response
+			var lastErr error
+
+			for attempt := 0; ; attempt++ {
+				if attempt > 0 {
+					if req.GetBody == nil && req.Body != nil {
+						// The body was already consumed by a previous
+						// attempt and can't be rewound, so we're stuck with
+						// whatever the last attempt returned.
+						return lastResponse, lastErr
+					}
+					if req.GetBody != nil {
+						body, err := req.GetBody()
+						if err != nil {
+							return nil, err
+						}
+						req.Body = body
+					}
+				}
+
+				response, err := next(req)
+				lastResponse, lastErr = response, err
+				if err != nil {
+					if attempt < policy.MaxRetries && req.Context().Err() == nil {
+						retry, checkErr := policy.checkRetry(nil, err)
+						if checkErr != nil {
+							return nil, checkErr
+						}
+						if retry && waitForRetry(req.Context(), policy.backoff(nil, attempt)) {
+							continue
+						}
+					}
+					return nil, err
+				}
+
+				if attempt < policy.MaxRetries && req.Context().Err() == nil {
+					probe := &Response{ResponseCommon: ResponseCommon{StatusCode: response.StatusCode, Header: response.Header}}
+					retry, checkErr := policy.checkRetry(probe, nil)
+					if checkErr != nil {
+						return nil, checkErr
+					}
+					if retry {
+						response.Body.Close()
+						if waitForRetry(req.Context(), policy.backoff(probe, attempt)) {
+							continue
+						}
+					}
+				}
+
+				return response, nil
+			}
+		}
+	}
+}
+
+// RateLimitMiddleware builds a Middleware that waits on c's configured
+// *rate.Limiter before every attempt (including retries) and reconfigures
+// it from the Ratelimit-* headers of every response. This is installed by
+// default in NewClientWithContext.
+func RateLimitMiddleware(c *Client) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if err := c.waitForRateLimiter(req.Context()); err != nil {
+				return nil, fmt.Errorf("Failed to wait for rate limiter: %s", err.Error())
+			}
+
+			response, err := next(req)
+			if err != nil {
+				return response, err
+			}
+
+			c.applyRateLimiterHeaders(&Response{ResponseCommon: ResponseCommon{StatusCode: response.StatusCode, Header: response.Header}})
+
+			return response, nil
+		}
+	}
+}
+
+// DebugLoggingMiddleware dumps every outgoing request and incoming response
+// to log, for local debugging. It's opt-in via Client.Use since dumping
+// bodies is expensive and can leak tokens into logs.
+func DebugLoggingMiddleware(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		if dump, err := httputil.DumpRequestOut(req, true); err == nil {
+			log.Printf("helix: request:\n%s", dump)
+		}
+
+		resp, err := next(req)
+		if err != nil {
+			return resp, err
+		}
+
+		if dump, err := httputil.DumpResponse(resp, true); err == nil {
+			log.Printf("helix: response:\n%s", dump)
+		}
+
+		return resp, err
+	}
+}
@@ -0,0 +1,115 @@
+package helix
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUseAppendsToMiddlewareOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := &Client{opts: &Options{HTTPClient: server.Client()}}
+
+	var order []string
+	mark := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next(req)
+			}
+		}
+	}
+
+	c.opts.Middlewares = append(c.opts.Middlewares, mark("first"))
+	c.Use(mark("second"))
+	c.Use(mark("third"))
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	resp, err := c.roundTripper()(req)
+	if err != nil {
+		t.Fatalf("roundTripper() error = %v", err)
+	}
+	resp.Body.Close()
+
+	want := []string{"first", "second", "third"}
+	if len(order) != len(want) {
+		t.Fatalf("call order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("call order = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestRetryMiddlewareRetriesOn5xx(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	policy := &RetryPolicy{MaxRetries: 3, MinWait: 0, MaxWait: 0, CheckRetry: DefaultCheckRetry}
+	next := RetryMiddleware(policy)(RoundTripFunc(server.Client().Do))
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	resp, err := next(req)
+	if err != nil {
+		t.Fatalf("RetryMiddleware round trip error = %v", err)
+	}
+	resp.Body.Close()
+
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("final StatusCode = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestRetryMiddlewareGivesUpAfterMaxRetries(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	policy := &RetryPolicy{MaxRetries: 2, MinWait: 0, MaxWait: 0, CheckRetry: DefaultCheckRetry}
+	next := RetryMiddleware(policy)(RoundTripFunc(server.Client().Do))
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	resp, err := next(req)
+	if err != nil {
+		t.Fatalf("RetryMiddleware round trip error = %v", err)
+	}
+	resp.Body.Close()
+
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3 (1 initial + MaxRetries 2)", attempts)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("final StatusCode = %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+}
@@ -0,0 +1,91 @@
+package helix
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimiterBurst is the burst size used when (re)configuring the limiter
+// from response headers; Twitch's limit windows are small enough that a
+// burst of 1 keeps requests evenly paced rather than allowed to spike.
+const rateLimiterBurst = 1
+
+// defaultRateLimiter is installed by NewClientWithContext when the caller
+// doesn't supply one, so pacing works out of the box without writing a
+// RateLimitFunc. It starts unlimited and is tightened by
+// applyRateLimiterHeaders as soon as the first response reports Twitch's
+// actual limit.
+func defaultRateLimiter() *rate.Limiter {
+	return rate.NewLimiter(rate.Inf, rateLimiterBurst)
+}
+
+// WithRateLimiter is a ClientOption that sets the *rate.Limiter used to pace
+// outgoing requests. This is primarily useful for sharing a single limiter
+// across multiple Client instances that share the same app token and are
+// therefore subject to the same Twitch rate limit.
+func WithRateLimiter(limiter *rate.Limiter) ClientOption {
+	return func(o *Options) {
+		o.RateLimiter = limiter
+	}
+}
+
+// SetRateLimiter sets the *rate.Limiter used to pace outgoing requests after
+// construction. See WithRateLimiter to set one at NewClient time.
+func (c *Client) SetRateLimiter(limiter *rate.Limiter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.opts.RateLimiter = limiter
+}
+
+// applyRateLimiterHeaders reconfigures the limiter's refill rate from the
+// Ratelimit-Limit/Ratelimit-Remaining/Ratelimit-Reset headers on resp, so
+// the next Wait call paces requests according to what Twitch actually
+// reports rather than a static guess.
+func (c *Client) applyRateLimiterHeaders(resp *Response) {
+	c.mu.RLock()
+	limiter := c.opts.RateLimiter
+	c.mu.RUnlock()
+
+	if limiter == nil {
+		return
+	}
+
+	limit := resp.GetRateLimit()
+	remaining := resp.GetRateLimitRemaining()
+	reset := resp.GetRateLimitReset()
+
+	if limit <= 0 || reset <= 0 {
+		return
+	}
+
+	window := time.Until(time.Unix(int64(reset), 0))
+	if window <= 0 {
+		return
+	}
+
+	// Spread the remaining budget evenly across the time left in the
+	// window, so Wait() naturally slows down as Remaining approaches zero.
+	every := window
+	if remaining > 0 {
+		every = window / time.Duration(remaining)
+	}
+
+	limiter.SetLimit(rate.Every(every))
+	limiter.SetBurst(rateLimiterBurst)
+}
+
+// waitForRateLimiter blocks until the configured rate limiter allows another
+// request to proceed, or ctx is done.
+func (c *Client) waitForRateLimiter(ctx context.Context) error {
+	c.mu.RLock()
+	limiter := c.opts.RateLimiter
+	c.mu.RUnlock()
+
+	if limiter == nil {
+		return nil
+	}
+
+	return limiter.Wait(ctx)
+}
@@ -0,0 +1,97 @@
+package helix
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func rateLimitHeaders(limit, remaining int, reset time.Time) http.Header {
+	h := http.Header{}
+	h.Set("RateLimit-Limit", strconv.Itoa(limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(remaining))
+	h.Set("RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	return h
+}
+
+func TestApplyRateLimiterHeadersRefillsFromWindow(t *testing.T) {
+	limiter := rate.NewLimiter(rate.Inf, rateLimiterBurst)
+	c := &Client{opts: &Options{RateLimiter: limiter}}
+
+	resp := &Response{ResponseCommon: ResponseCommon{
+		Header: rateLimitHeaders(800, 80, time.Now().Add(80*time.Second)),
+	}}
+
+	c.applyRateLimiterHeaders(resp)
+
+	// 80 requests left over ~80s works out to about 1 req/sec.
+	got := float64(limiter.Limit())
+	if got < 0.9 || got > 1.1 {
+		t.Errorf("Limit() = %v, want ~1.0 (80 remaining over 80s)", got)
+	}
+	if limiter.Burst() != rateLimiterBurst {
+		t.Errorf("Burst() = %d, want %d", limiter.Burst(), rateLimiterBurst)
+	}
+}
+
+func TestApplyRateLimiterHeadersIgnoresExpiredWindow(t *testing.T) {
+	limiter := rate.NewLimiter(rate.Inf, rateLimiterBurst)
+	c := &Client{opts: &Options{RateLimiter: limiter}}
+
+	resp := &Response{ResponseCommon: ResponseCommon{
+		Header: rateLimitHeaders(800, 80, time.Now().Add(-time.Second)),
+	}}
+
+	c.applyRateLimiterHeaders(resp)
+
+	if limiter.Limit() != rate.Inf {
+		t.Errorf("Limit() = %v, want unchanged rate.Inf for an already-elapsed reset window", limiter.Limit())
+	}
+}
+
+func TestApplyRateLimiterHeadersNoopWithoutLimiter(t *testing.T) {
+	c := &Client{opts: &Options{}}
+
+	resp := &Response{ResponseCommon: ResponseCommon{
+		Header: rateLimitHeaders(800, 80, time.Now().Add(80*time.Second)),
+	}}
+
+	// Must not panic when no RateLimiter is configured.
+	c.applyRateLimiterHeaders(resp)
+}
+
+func TestWaitForRateLimiterNoopWithoutLimiter(t *testing.T) {
+	c := &Client{opts: &Options{}}
+
+	if err := c.waitForRateLimiter(context.Background()); err != nil {
+		t.Errorf("waitForRateLimiter() error = %v, want nil", err)
+	}
+}
+
+func TestSetRateLimiterConcurrentWithWait(t *testing.T) {
+	c := &Client{opts: &Options{RateLimiter: rate.NewLimiter(rate.Inf, rateLimiterBurst)}}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			c.SetRateLimiter(rate.NewLimiter(rate.Inf, rateLimiterBurst))
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_ = c.waitForRateLimiter(context.Background())
+		}
+	}()
+
+	wg.Wait()
+}
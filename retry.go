@@ -0,0 +1,133 @@
+package helix
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// DefaultRetryMax is the default number of retry attempts for a request that
+// qualifies for retry (see CheckRetry).
+const DefaultRetryMax = 3
+
+// DefaultRetryWaitMin is the default minimum wait time between retries.
+const DefaultRetryWaitMin = 1 * time.Second
+
+// DefaultRetryWaitMax is the default maximum wait time between retries.
+const DefaultRetryWaitMax = 30 * time.Second
+
+// CheckRetry decides whether a request should be retried based on the
+// response and/or error returned by a single attempt. A nil Response
+// indicates the attempt failed before a response was received (e.g. a
+// network error).
+type CheckRetry func(resp *Response, err error) (bool, error)
+
+// RetryPolicy controls how doRequest retries failed requests. The zero value
+// is not usable; use DefaultRetryPolicy or construct one explicitly.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retry attempts after the initial
+	// request. A value of 0 disables retrying.
+	MaxRetries int
+
+	// MinWait is the base wait duration used for exponential backoff.
+	MinWait time.Duration
+
+	// MaxWait caps the backoff duration, including jitter.
+	MaxWait time.Duration
+
+	// CheckRetry decides whether a given attempt should be retried. If nil,
+	// DefaultCheckRetry is used.
+	CheckRetry CheckRetry
+}
+
+// DefaultRetryPolicy returns a RetryPolicy with sane defaults so callers
+// don't need to write a RateLimitFunc just to get reasonable handling of
+// transient failures and 429s.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries: DefaultRetryMax,
+		MinWait:    DefaultRetryWaitMin,
+		MaxWait:    DefaultRetryWaitMax,
+		CheckRetry: DefaultCheckRetry,
+	}
+}
+
+// DefaultCheckRetry retries on network errors and on 429/500/502/503/504
+// responses.
+func DefaultCheckRetry(resp *Response, err error) (bool, error) {
+	if err != nil {
+		return true, nil
+	}
+
+	if resp == nil {
+		return false, nil
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// checkRetry delegates to the policy's CheckRetry, falling back to
+// DefaultCheckRetry when none was supplied.
+func (p *RetryPolicy) checkRetry(resp *Response, err error) (bool, error) {
+	checkRetry := p.CheckRetry
+	if checkRetry == nil {
+		checkRetry = DefaultCheckRetry
+	}
+
+	return checkRetry(resp, err)
+}
+
+// waitForRetry blocks for d, returning false early if ctx is done first.
+func waitForRetry(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// backoff computes how long to wait before the next attempt. For a 429 with
+// a valid RateLimit-Reset header it waits until that unix time, but never
+// longer than MaxWait, so a reset further away than MaxWait is retried
+// before it; otherwise it falls back to exponential backoff with ±25%
+// jitter, capped at MaxWait.
+func (p *RetryPolicy) backoff(resp *Response, attempt int) time.Duration {
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		if reset := resp.GetRateLimitReset(); reset > 0 {
+			if wait := time.Until(time.Unix(int64(reset), 0)); wait > 0 {
+				if wait > p.MaxWait {
+					return p.MaxWait
+				}
+				return wait
+			}
+		}
+	}
+
+	wait := p.MinWait * time.Duration(1<<uint(attempt))
+	if wait <= 0 || wait > p.MaxWait {
+		wait = p.MaxWait
+	}
+
+	jitter := time.Duration(float64(wait) * 0.25 * (2*rand.Float64() - 1))
+
+	wait += jitter
+	if wait > p.MaxWait {
+		wait = p.MaxWait
+	}
+
+	return wait
+}
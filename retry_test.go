@@ -0,0 +1,126 @@
+package helix
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestDefaultCheckRetry(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *Response
+		err  error
+		want bool
+	}{
+		{"network error", nil, errors.New("connection reset"), true},
+		{"nil response, no error", nil, nil, false},
+		{"429", &Response{ResponseCommon: ResponseCommon{StatusCode: http.StatusTooManyRequests}}, nil, true},
+		{"500", &Response{ResponseCommon: ResponseCommon{StatusCode: http.StatusInternalServerError}}, nil, true},
+		{"502", &Response{ResponseCommon: ResponseCommon{StatusCode: http.StatusBadGateway}}, nil, true},
+		{"503", &Response{ResponseCommon: ResponseCommon{StatusCode: http.StatusServiceUnavailable}}, nil, true},
+		{"504", &Response{ResponseCommon: ResponseCommon{StatusCode: http.StatusGatewayTimeout}}, nil, true},
+		{"200", &Response{ResponseCommon: ResponseCommon{StatusCode: http.StatusOK}}, nil, false},
+		{"404", &Response{ResponseCommon: ResponseCommon{StatusCode: http.StatusNotFound}}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DefaultCheckRetry(tt.resp, tt.err)
+			if err != nil {
+				t.Fatalf("DefaultCheckRetry() error = %v, want nil", err)
+			}
+			if got != tt.want {
+				t.Errorf("DefaultCheckRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoffExponentialWithJitter(t *testing.T) {
+	policy := &RetryPolicy{
+		MinWait: 1 * time.Second,
+		MaxWait: 30 * time.Second,
+	}
+
+	for attempt := 0; attempt < 6; attempt++ {
+		base := policy.MinWait * time.Duration(1<<uint(attempt))
+		if base > policy.MaxWait {
+			base = policy.MaxWait
+		}
+
+		min := time.Duration(float64(base) * 0.75)
+		max := time.Duration(float64(base) * 1.25)
+		if min > policy.MaxWait {
+			min = policy.MaxWait
+		}
+		if max > policy.MaxWait {
+			max = policy.MaxWait
+		}
+
+		for i := 0; i < 20; i++ {
+			wait := policy.backoff(nil, attempt)
+			if wait < min || wait > max {
+				t.Fatalf("attempt %d: backoff() = %s, want in [%s, %s]", attempt, wait, min, max)
+			}
+		}
+	}
+}
+
+func TestRetryPolicyBackoffCapsAtMaxWait(t *testing.T) {
+	policy := &RetryPolicy{
+		MinWait: 1 * time.Second,
+		MaxWait: 5 * time.Second,
+	}
+
+	wait := policy.backoff(nil, 10)
+	if wait > policy.MaxWait {
+		t.Errorf("backoff() = %s, want <= MaxWait %s", wait, policy.MaxWait)
+	}
+}
+
+func TestRetryPolicyBackoffUsesRateLimitReset(t *testing.T) {
+	policy := &RetryPolicy{
+		MinWait: 1 * time.Second,
+		MaxWait: 30 * time.Second,
+	}
+
+	reset := time.Now().Add(10 * time.Second)
+	header := http.Header{}
+	header.Set("RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	resp := &Response{
+		ResponseCommon: ResponseCommon{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     header,
+		},
+	}
+
+	wait := policy.backoff(resp, 0)
+	if wait < 9*time.Second || wait > 10*time.Second {
+		t.Errorf("backoff() = %s, want ~10s (until RateLimit-Reset)", wait)
+	}
+}
+
+func TestRetryPolicyBackoffClampsRateLimitResetToMaxWait(t *testing.T) {
+	policy := &RetryPolicy{
+		MinWait: 1 * time.Second,
+		MaxWait: 5 * time.Second,
+	}
+
+	reset := time.Now().Add(1 * time.Minute)
+	header := http.Header{}
+	header.Set("RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	resp := &Response{
+		ResponseCommon: ResponseCommon{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     header,
+		},
+	}
+
+	wait := policy.backoff(resp, 0)
+	if wait != policy.MaxWait {
+		t.Errorf("backoff() = %s, want MaxWait %s", wait, policy.MaxWait)
+	}
+}
@@ -0,0 +1,31 @@
+package helix
+
+import "strings"
+
+// libraryVersion is the current module version, included as the last
+// component of the default User-Agent so Twitch can attribute traffic to
+// this library even when the caller stacks their own identifiers in front
+// of it.
+const libraryVersion = "1.25.0"
+
+// UserAgent builds a composable User-Agent header out of prepended
+// identifiers (e.g. an app name, a sub-library) joined in front of a
+// default "helix/X.Y.Z (go/helix)" suffix, following the prepend/join pattern
+// used by gophercloud's UserAgent.
+type UserAgent struct {
+	prepend []string
+}
+
+// Prepend adds one or more identifiers to the front of the User-Agent,
+// outermost caller first. Repeated calls keep appending in order, so the
+// last call to Prepend ends up closest to the default helix suffix.
+func (ua *UserAgent) Prepend(s ...string) {
+	ua.prepend = append(ua.prepend, s...)
+}
+
+// Join renders the full User-Agent string: every prepended identifier,
+// space-separated, followed by the default helix identifier.
+func (ua *UserAgent) Join() string {
+	parts := append(append([]string{}, ua.prepend...), "helix/"+libraryVersion+" (go/helix)")
+	return strings.Join(parts, " ")
+}
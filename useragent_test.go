@@ -0,0 +1,40 @@
+package helix
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUserAgentJoinDefaultsToLibrarySuffix(t *testing.T) {
+	var ua UserAgent
+
+	got := ua.Join()
+	want := "helix/" + libraryVersion + " (go/helix)"
+	if got != want {
+		t.Errorf("Join() = %q, want %q", got, want)
+	}
+}
+
+func TestUserAgentPrependOrdersOutermostFirst(t *testing.T) {
+	var ua UserAgent
+
+	ua.Prepend("myapp/1.0")
+	ua.Prepend("sublib/2.0")
+
+	got := ua.Join()
+	want := "myapp/1.0 sublib/2.0 helix/" + libraryVersion + " (go/helix)"
+	if got != want {
+		t.Errorf("Join() = %q, want %q", got, want)
+	}
+}
+
+func TestUserAgentPrependVariadic(t *testing.T) {
+	var ua UserAgent
+
+	ua.Prepend("a", "b", "c")
+
+	got := ua.Join()
+	if !strings.HasPrefix(got, "a b c ") {
+		t.Errorf("Join() = %q, want prefix %q", got, "a b c ")
+	}
+}